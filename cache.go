@@ -0,0 +1,252 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"ai-helpdeskk/bedrock-service/provider"
+)
+
+// CacheEntry is what's stored per cache key: the generated result plus enough
+// metadata to reconstruct a GenerateResponse without recalling the model.
+type CacheEntry struct {
+	Response     string
+	ModelUsed    string
+	ProviderUsed string
+	Usage        provider.Usage
+}
+
+// Cache stores generation results keyed by a hash of the request parameters
+// that determine the output. Two backends implement it: inMemoryCache
+// (default) and redisCache (selected when REDIS_URL is set).
+type Cache interface {
+	Get(ctx context.Context, key string) (CacheEntry, bool, error)
+	Set(ctx context.Context, key string, entry CacheEntry) error
+	// DeleteByModel removes cached entries for model, or every entry if
+	// model is empty, returning the number removed.
+	DeleteByModel(ctx context.Context, model string) (int, error)
+}
+
+// cacheKey hashes the parameters that fully determine a generation's output,
+// so identical requests short-circuit to the same cached response. provider
+// must be included: otherwise a provider-pinned request (chunk0-2's
+// GenerateRequest.Provider) can be served another provider's cached answer
+// for the same prompt/model string.
+func cacheKey(provider, model string, maxTokens int, temperature float64, systemPrompt, prompt string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%d|%g|%s|%s", provider, model, maxTokens, temperature, systemPrompt, prompt)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// buildCache selects a Cache backend from the environment: Redis when
+// REDIS_URL is set, otherwise an in-process LRU sized by CACHE_CAPACITY
+// (default 1000) and CACHE_TTL_SECONDS (default 3600).
+func buildCache() (Cache, error) {
+	capacity := 1000
+	if raw := os.Getenv("CACHE_CAPACITY"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			capacity = n
+		}
+	}
+
+	ttl := time.Hour
+	if raw := os.Getenv("CACHE_TTL_SECONDS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			ttl = time.Duration(n) * time.Second
+		}
+	}
+
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		return newRedisCache(url, ttl)
+	}
+
+	return newInMemoryCache(capacity, ttl), nil
+}
+
+// inMemoryCache is an LRU capped by entry count, with a fixed TTL per entry.
+type inMemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type inMemoryItem struct {
+	key       string
+	entry     CacheEntry
+	expiresAt time.Time
+}
+
+func newInMemoryCache(capacity int, ttl time.Duration) *inMemoryCache {
+	return &inMemoryCache{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *inMemoryCache) Get(ctx context.Context, key string) (CacheEntry, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false, nil
+	}
+
+	item := el.Value.(*inMemoryItem)
+	if time.Now().After(item.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return CacheEntry{}, false, nil
+	}
+
+	c.ll.MoveToFront(el)
+	return item.entry, true, nil
+}
+
+func (c *inMemoryCache) Set(ctx context.Context, key string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		item := el.Value.(*inMemoryItem)
+		item.entry = entry
+		item.expiresAt = time.Now().Add(c.ttl)
+		c.ll.MoveToFront(el)
+		return nil
+	}
+
+	el := c.ll.PushFront(&inMemoryItem{key: key, entry: entry, expiresAt: time.Now().Add(c.ttl)})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*inMemoryItem).key)
+		}
+	}
+	return nil
+}
+
+func (c *inMemoryCache) DeleteByModel(ctx context.Context, model string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for el := c.ll.Front(); el != nil; {
+		next := el.Next()
+		item := el.Value.(*inMemoryItem)
+		if model == "" || item.entry.ModelUsed == model {
+			c.ll.Remove(el)
+			delete(c.items, item.key)
+			removed++
+		}
+		el = next
+	}
+	return removed, nil
+}
+
+// redisCache stores entries as JSON under a fixed key prefix, with TTL set on
+// each write so Redis reclaims stale entries itself.
+type redisCache struct {
+	client *redis.Client
+	ttl    time.Duration
+	prefix string
+}
+
+func newRedisCache(url string, ttl time.Duration) (*redisCache, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %v", err)
+	}
+	return &redisCache{client: redis.NewClient(opts), ttl: ttl, prefix: "bedrock:cache:"}, nil
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) (CacheEntry, bool, error) {
+	raw, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if err == redis.Nil {
+		return CacheEntry{}, false, nil
+	}
+	if err != nil {
+		return CacheEntry{}, false, err
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return CacheEntry{}, false, fmt.Errorf("error parsing cached entry: %v", err)
+	}
+	return entry, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, entry CacheEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling cache entry: %v", err)
+	}
+	return c.client.Set(ctx, c.prefix+key, raw, c.ttl).Err()
+}
+
+func (c *redisCache) DeleteByModel(ctx context.Context, model string) (int, error) {
+	removed := 0
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, c.prefix+"*", 100).Result()
+		if err != nil {
+			return removed, err
+		}
+
+		for _, key := range keys {
+			if model != "" {
+				raw, err := c.client.Get(ctx, key).Bytes()
+				if err != nil {
+					continue
+				}
+				var entry CacheEntry
+				if err := json.Unmarshal(raw, &entry); err != nil || entry.ModelUsed != model {
+					continue
+				}
+			}
+			if err := c.client.Del(ctx, key).Err(); err == nil {
+				removed++
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return removed, nil
+}
+
+// cacheHandler serves DELETE /cache, optionally filtered to a single model via
+// ?model=.
+func cacheHandler(cache Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		model := r.URL.Query().Get("model")
+
+		removed, err := cache.DeleteByModel(r.Context(), model)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error clearing cache: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"removed": removed})
+	}
+}
@@ -1,358 +1,361 @@
 package main
 
 import (
-    "context"
-    "encoding/json"
-    "fmt"
-    "log"
-    "net/http"
-    "os"
-    "strings"
-    "time"
-
-    "github.com/aws/aws-sdk-go-v2/aws"
-    "github.com/aws/aws-sdk-go-v2/config"
-    "github.com/aws/aws-sdk-go-v2/credentials"
-    "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
-    "github.com/gorilla/mux"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"ai-helpdeskk/bedrock-service/provider"
+	"ai-helpdeskk/bedrock-service/providers/bedrock"
+	"ai-helpdeskk/bedrock-service/providers/gemini"
+	"ai-helpdeskk/bedrock-service/providers/openaicompat"
 )
 
 type GenerateRequest struct {
-    Prompt      string  `json:"prompt"`
-    MaxTokens   int     `json:"max_tokens,omitempty"`
-    Temperature float64 `json:"temperature,omitempty"`
-    Model       string  `json:"model,omitempty"`
+	Prompt      string  `json:"prompt"`
+	MaxTokens   int     `json:"max_tokens,omitempty"`
+	Temperature float64 `json:"temperature,omitempty"`
+	// Model matches against a registered ModelInfo's Name or ID; ID may be a
+	// short on-demand model ID or a full inference-profile ARN.
+	Model string `json:"model,omitempty"`
+	// Provider selects which registered Provider serves this request (e.g.
+	// "bedrock", "google", "openai"). Empty uses the service's default order.
+	Provider string `json:"provider,omitempty"`
 }
 
 type GenerateResponse struct {
-    Response   string `json:"response"`
-    ModelUsed  string `json:"model_used"`
-    TokenCount int    `json:"token_count,omitempty"`
+	Response     string `json:"response"`
+	ModelUsed    string `json:"model_used"`
+	ProviderUsed string `json:"provider_used"`
+	TokenCount   int    `json:"token_count,omitempty"`
+	InputTokens  int    `json:"input_tokens,omitempty"`
+	OutputTokens int    `json:"output_tokens,omitempty"`
+	LatencyMs    int64  `json:"latency_ms,omitempty"`
+	Cached       bool   `json:"cached"`
 }
 
 type HealthResponse struct {
-    Status         string   `json:"status"`
-    Service        string   `json:"service"`
-    AvailableModels []string `json:"available_models"`
+	Status           string              `json:"status"`
+	Service          string              `json:"service"`
+	AvailableModels  []string            `json:"available_models"`
+	ModelsByProvider map[string][]string `json:"models_by_provider"`
 }
 
-type ModelInfo struct {
-    ID          string
-    Name        string
-    Available   bool
-    MessageAPI  bool
+type EmbedRequest struct {
+	Input []string `json:"input"`
+	Model string   `json:"model,omitempty"`
 }
 
-type BedrockClient struct {
-    client         *bedrockruntime.Client
-    availableModels []ModelInfo
+type EmbedResponse struct {
+	Vectors    [][]float32 `json:"vectors"`
+	ModelUsed  string      `json:"model_used"`
+	TokenCount int         `json:"token_count,omitempty"`
 }
 
-func NewBedrockClient() (*BedrockClient, error) {
-    awsAccessKey := os.Getenv("AWS_ACCESS_KEY_ID")
-    awsSecretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
-    awsRegion := os.Getenv("AWS_REGION")
-    
-    if awsRegion == "" {
-        awsRegion = "us-east-1"
-    }
-
-    if awsAccessKey == "" || awsSecretKey == "" {
-        return nil, fmt.Errorf("AWS credentials not provided")
-    }
-
-    cfg, err := config.LoadDefaultConfig(context.TODO(),
-        config.WithRegion(awsRegion),
-        config.WithCredentialsProvider(
-            credentials.NewStaticCredentialsProvider(awsAccessKey, awsSecretKey, ""),
-        ),
-    )
-    if err != nil {
-        return nil, fmt.Errorf("unable to load SDK config: %v", err)
-    }
-
-    client := bedrockruntime.NewFromConfig(cfg)
-    
-    availableModels := []ModelInfo{
-        {ID: "anthropic.claude-3-5-sonnet-20241022-v2:0", Name: "Claude 3.5 Sonnet v2", MessageAPI: true},
-        {ID: "anthropic.claude-3-5-sonnet-20240620-v1:0", Name: "Claude 3.5 Sonnet", MessageAPI: true},
-        {ID: "anthropic.claude-3-5-haiku-20241022-v1:0", Name: "Claude 3.5 Haiku", MessageAPI: true},
-        {ID: "anthropic.claude-3-sonnet-20240229-v1:0", Name: "Claude 3 Sonnet", MessageAPI: true},
-        {ID: "anthropic.claude-3-haiku-20240307-v1:0", Name: "Claude 3 Haiku", MessageAPI: true},
-        {ID: "anthropic.claude-v2:1", Name: "Claude v2.1", MessageAPI: false},
-        {ID: "anthropic.claude-v2", Name: "Claude v2", MessageAPI: false},
-    }
-    
-    return &BedrockClient{
-        client: client,
-        availableModels: availableModels,
-    }, nil
+func healthHandler(registry *provider.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		byProvider := registry.ModelsByProvider()
+
+		var all []string
+		for _, models := range byProvider {
+			all = append(all, models...)
+		}
+
+		response := HealthResponse{
+			Status:           "healthy",
+			Service:          "bedrock-service",
+			AvailableModels:  all,
+			ModelsByProvider: byProvider,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
 }
 
-func (bc *BedrockClient) TestModelAvailability() {
-    log.Println("Testing model availability...")
-    
-    testPrompt := "Hello"
-    
-    for i := range bc.availableModels {
-        model := &bc.availableModels[i]
-        
-        var requestBody map[string]interface{}
-        
-        if model.MessageAPI {
-            requestBody = map[string]interface{}{
-                "anthropic_version": "bedrock-2023-05-31",
-                "max_tokens": 10,
-                "messages": []map[string]string{
-                    {
-                        "role": "user",
-                        "content": testPrompt,
-                    },
-                },
-            }
-        } else {
-            requestBody = map[string]interface{}{
-                "prompt": fmt.Sprintf("\n\nHuman: %s\n\nAssistant:", testPrompt),
-                "max_tokens_to_sample": 10,
-            }
-        }
-
-        bodyBytes, _ := json.Marshal(requestBody)
-        
-        ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-        _, err := bc.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
-            Body:        bodyBytes,
-            ModelId:     aws.String(model.ID),
-            ContentType: aws.String("application/json"),
-        })
-        cancel()
-        
-        if err != nil {
-            log.Printf("Model %s (%s): UNAVAILABLE - %v", model.Name, model.ID, err)
-            model.Available = false
-        } else {
-            log.Printf("Model %s (%s): AVAILABLE ✓", model.Name, model.ID)
-            model.Available = true
-        }
-    }
-}
-
-func (bc *BedrockClient) GetAvailableModels() []string {
-    var available []string
-    for _, model := range bc.availableModels {
-        if model.Available {
-            available = append(available, model.Name)
-        }
-    }
-    return available
+func rootHandler(w http.ResponseWriter, r *http.Request) {
+	response := map[string]string{
+		"message":  "Bedrock Service is running",
+		"version":  "1.0.0",
+		"features": "conversation-context, file-analysis, multi-model-support",
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
 
-func (bc *BedrockClient) GenerateText(prompt string, preferredModel string, maxTokens int, temperature float64) (string, string, error) {
-    if maxTokens == 0 {
-        maxTokens = 2000
-    }
-    if temperature == 0 {
-        temperature = 0.7
-    }
-
-    var modelsToTry []ModelInfo
-    if preferredModel != "" {
-        for _, model := range bc.availableModels {
-            if model.Available && (strings.Contains(strings.ToLower(model.Name), strings.ToLower(preferredModel)) || 
-                                 strings.Contains(strings.ToLower(model.ID), strings.ToLower(preferredModel))) {
-                modelsToTry = append(modelsToTry, model)
-                break
-            }
-        }
-    }
-    
-    for _, model := range bc.availableModels {
-        if model.Available {
-            found := false
-            for _, existing := range modelsToTry {
-                if existing.ID == model.ID {
-                    found = true
-                    break
-                }
-            }
-            if !found {
-                modelsToTry = append(modelsToTry, model)
-            }
-        }
-    }
-    
-    if len(modelsToTry) == 0 {
-        return "", "", fmt.Errorf("no available models found")
-    }
-    
-    var lastError error
-    for _, model := range modelsToTry {
-        log.Printf("Trying model: %s (%s)", model.Name, model.ID)
-        
-        var requestBody map[string]interface{}
-        
-        if model.MessageAPI {
-            systemPrompt := "You are a helpful AI assistant with access to conversation history and uploaded files. " +
-                           "When responding, consider the full context provided, including previous conversations and any file content. " +
-                           "If file content is mentioned in the context, analyze and reference it appropriately in your response. " +
-                           "Be conversational, helpful, and maintain continuity with previous interactions."
-            
-            requestBody = map[string]interface{}{
-                "anthropic_version": "bedrock-2023-05-31",
-                "max_tokens": maxTokens,
-                "system": systemPrompt,
-                "messages": []map[string]interface{}{
-                    {
-                        "role": "user",
-                        "content": prompt,
-                    },
-                },
-                "temperature": temperature,
-            }
-        } else {
-            enhancedPrompt := fmt.Sprintf("\n\nHuman: You are a helpful AI assistant with conversation memory and file analysis capabilities. Please provide thoughtful, contextual responses based on the information provided.\n\n%s\n\nAssistant:", prompt)
-            
-            requestBody = map[string]interface{}{
-                "prompt": enhancedPrompt,
-                "max_tokens_to_sample": maxTokens,
-                "temperature": temperature,
-            }
-        }
-
-        bodyBytes, err := json.Marshal(requestBody)
-        if err != nil {
-            lastError = fmt.Errorf("error marshaling request: %v", err)
-            continue
-        }
-
-        ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-        resp, err := bc.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
-            Body:        bodyBytes,
-            ModelId:     aws.String(model.ID),
-            ContentType: aws.String("application/json"),
-        })
-        cancel()
-        
-        if err != nil {
-            lastError = err
-            log.Printf("Error with model %s: %v", model.Name, err)
-            continue
-        }
-
-        var response map[string]interface{}
-        if err := json.Unmarshal(resp.Body, &response); err != nil {
-            lastError = fmt.Errorf("error parsing response: %v", err)
-            continue
-        }
-
-        if model.MessageAPI {
-            if content, ok := response["content"].([]interface{}); ok && len(content) > 0 {
-                if firstContent, ok := content[0].(map[string]interface{}); ok {
-                    if text, ok := firstContent["text"].(string); ok {
-                        log.Printf("✓ Successfully used model: %s", model.Name)
-                        return text, model.Name, nil
-                    }
-                }
-            }
-        } else {
-            if completion, ok := response["completion"].(string); ok {
-                log.Printf("✓ Successfully used model: %s", model.Name)
-                return completion, model.Name, nil
-            }
-        }
-        
-        lastError = fmt.Errorf("unexpected response format from model %s", model.Name)
-    }
-
-    return "", "", fmt.Errorf("all available models failed. Last error: %v", lastError)
+func generateHandler(registry *provider.Registry, cache Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GenerateRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if req.Prompt == "" {
+			http.Error(w, "Prompt is required", http.StatusBadRequest)
+			return
+		}
+
+		log.Printf("Received prompt: %s", req.Prompt[:min(100, len(req.Prompt))])
+
+		opts := provider.GenerateOptions{Model: req.Model, MaxTokens: req.MaxTokens, Temperature: req.Temperature}.WithDefaults()
+		key := cacheKey(req.Provider, req.Model, opts.MaxTokens, opts.Temperature, bedrock.DefaultSystemPrompt, req.Prompt)
+
+		if entry, ok, err := cache.Get(r.Context(), key); err == nil && ok {
+			cacheHits.Inc()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(GenerateResponse{
+				Response:     entry.Response,
+				ModelUsed:    entry.ModelUsed,
+				ProviderUsed: entry.ProviderUsed,
+				TokenCount:   entry.Usage.InputTokens + entry.Usage.OutputTokens,
+				InputTokens:  entry.Usage.InputTokens,
+				OutputTokens: entry.Usage.OutputTokens,
+				Cached:       true,
+			})
+			return
+		}
+		cacheMisses.Inc()
+
+		start := time.Now()
+		response, modelUsed, providerUsed, usage, err := registry.Generate(r.Context(), req.Prompt, req.Provider, opts)
+		duration := time.Since(start)
+
+		metricsModel := modelUsed
+		if metricsModel == "" {
+			metricsModel = "unknown"
+		}
+		recordGeneration(metricsModel, usage, duration, err)
+
+		if err != nil {
+			log.Printf("Error generating text: %v", err)
+			http.Error(w, fmt.Sprintf("Error generating response: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		if err := cache.Set(r.Context(), key, CacheEntry{Response: response, ModelUsed: modelUsed, ProviderUsed: providerUsed, Usage: usage}); err != nil {
+			log.Printf("Error writing to cache: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(GenerateResponse{
+			Response:     response,
+			ModelUsed:    modelUsed,
+			ProviderUsed: providerUsed,
+			TokenCount:   usage.InputTokens + usage.OutputTokens,
+			InputTokens:  usage.InputTokens,
+			OutputTokens: usage.OutputTokens,
+			LatencyMs:    duration.Milliseconds(),
+		})
+	}
 }
 
-func healthHandler(bc *BedrockClient) http.HandlerFunc {
-    return func(w http.ResponseWriter, r *http.Request) {
-        response := HealthResponse{
-            Status:          "healthy",
-            Service:         "bedrock-service",
-            AvailableModels: bc.GetAvailableModels(),
-        }
-        w.Header().Set("Content-Type", "application/json")
-        json.NewEncoder(w).Encode(response)
-    }
+// streamIdleTimeout bounds the gap between consecutive SSE writes, not the
+// total lifetime of the stream; it's reset after every chunk.
+const streamIdleTimeout = 120 * time.Second
+
+// generateStreamHandler serves Server-Sent Events: one `data:` frame per response delta,
+// followed by a terminal `event: done` frame, or an `event: error` frame if generation
+// fails, whether before any delta was written or partway through the stream.
+func generateStreamHandler(bc *bedrock.Client, cache Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req GenerateRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if req.Prompt == "" {
+			http.Error(w, "Prompt is required", http.StatusBadRequest)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		log.Printf("Received streaming prompt: %s", req.Prompt[:min(100, len(req.Prompt))])
+
+		// The server's blanket WriteTimeout bounds single-shot JSON handlers,
+		// but would kill a long-running stream mid-flight even though it's
+		// still making progress. Reset an idle deadline on every write instead,
+		// so only a gap between chunks longer than streamIdleTimeout aborts it.
+		rc := http.NewResponseController(w)
+		resetWriteDeadline := func() {
+			if err := rc.SetWriteDeadline(time.Now().Add(streamIdleTimeout)); err != nil {
+				log.Printf("warning: could not extend stream write deadline: %v", err)
+			}
+		}
+		resetWriteDeadline()
+
+		writeEvent := func(event, data string) {
+			if event != "" {
+				fmt.Fprintf(w, "event: %s\n", event)
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+			resetWriteDeadline()
+		}
+
+		opts := provider.GenerateOptions{Model: req.Model, MaxTokens: req.MaxTokens, Temperature: req.Temperature}.WithDefaults()
+		key := cacheKey(req.Provider, req.Model, opts.MaxTokens, opts.Temperature, bedrock.DefaultSystemPrompt, req.Prompt)
+
+		// Streaming responses are captured into a buffer and cached on
+		// completion, so a later non-streaming request for the same prompt
+		// can still hit the cache.
+		var generated strings.Builder
+
+		start := time.Now()
+		modelUsed, usage, err := bc.GenerateTextStream(r.Context(), req.Prompt, req.Model, req.MaxTokens, req.Temperature, func(delta string) {
+			generated.WriteString(delta)
+			payload, _ := json.Marshal(map[string]string{"delta": delta})
+			writeEvent("", string(payload))
+		})
+		duration := time.Since(start)
+
+		metricsModel := modelUsed
+		if metricsModel == "" {
+			metricsModel = "unknown"
+		}
+		recordGeneration(metricsModel, usage, duration, err)
+
+		if err != nil {
+			log.Printf("Error streaming response: %v", err)
+			payload, _ := json.Marshal(map[string]string{"error": err.Error()})
+			writeEvent("error", string(payload))
+			return
+		}
+
+		if err := cache.Set(r.Context(), key, CacheEntry{Response: generated.String(), ModelUsed: modelUsed, ProviderUsed: bc.Name(), Usage: usage}); err != nil {
+			log.Printf("Error writing to cache: %v", err)
+		}
+
+		payload, _ := json.Marshal(map[string]string{
+			"model_used":    modelUsed,
+			"input_tokens":  fmt.Sprintf("%d", usage.InputTokens),
+			"output_tokens": fmt.Sprintf("%d", usage.OutputTokens),
+		})
+		writeEvent("done", string(payload))
+	}
 }
 
-func rootHandler(w http.ResponseWriter, r *http.Request) {
-    response := map[string]string{
-        "message": "Bedrock Service is running",
-        "version": "1.0.0",
-        "features": "conversation-context, file-analysis, multi-model-support",
-    }
-    w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(response)
+func embeddingsHandler(bc *bedrock.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req EmbedRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if len(req.Input) == 0 {
+			http.Error(w, "Input is required", http.StatusBadRequest)
+			return
+		}
+
+		vectors, modelUsed, tokenCount, err := bc.Embed(r.Context(), req.Input, req.Model)
+		if err != nil {
+			log.Printf("Error generating embeddings: %v", err)
+			http.Error(w, fmt.Sprintf("Error generating embeddings: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EmbedResponse{
+			Vectors:    vectors,
+			ModelUsed:  modelUsed,
+			TokenCount: tokenCount,
+		})
+	}
 }
 
-func generateHandler(bc *BedrockClient) http.HandlerFunc {
-    return func(w http.ResponseWriter, r *http.Request) {
-        var req GenerateRequest
-        
-        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-            http.Error(w, "Invalid request body", http.StatusBadRequest)
-            return
-        }
-
-        if req.Prompt == "" {
-            http.Error(w, "Prompt is required", http.StatusBadRequest)
-            return
-        }
-
-        log.Printf("Received prompt: %s", req.Prompt[:min(100, len(req.Prompt))])
-
-        response, modelUsed, err := bc.GenerateText(req.Prompt, req.Model, req.MaxTokens, req.Temperature)
-        if err != nil {
-            log.Printf("Error generating text: %v", err)
-            http.Error(w, fmt.Sprintf("Error generating response: %v", err), http.StatusInternalServerError)
-            return
-        }
-
-        w.Header().Set("Content-Type", "application/json")
-        json.NewEncoder(w).Encode(GenerateResponse{
-            Response:  response,
-            ModelUsed: modelUsed,
-        })
-    }
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
 
-func min(a, b int) int {
-    if a < b {
-        return a
-    }
-    return b
+// buildRegistry registers a Provider for each backend that has credentials
+// configured in the environment. At least one provider must be configured or
+// the service has nothing to serve requests with.
+func buildRegistry() (*provider.Registry, *bedrock.Client, error) {
+	registry := provider.NewRegistry()
+	var bc *bedrock.Client
+
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
+		client, err := bedrock.NewClient()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize Bedrock client: %v", err)
+		}
+		client.TestModelAvailability()
+		registry.Register(client)
+		bc = client
+	}
+
+	if os.Getenv("GOOGLE_API_KEY") != "" {
+		registry.Register(gemini.New())
+	}
+
+	if os.Getenv("OPENAI_BASE_URL") != "" {
+		registry.Register(openaicompat.New())
+	}
+
+	if registry.Len() == 0 {
+		return nil, nil, fmt.Errorf("no providers configured: set AWS_*, GOOGLE_API_KEY, or OPENAI_BASE_URL/OPENAI_API_KEY")
+	}
+
+	return registry, bc, nil
 }
 
 func main() {
-    log.Println("Starting Bedrock Service...")
-    
-    bc, err := NewBedrockClient()
-    if err != nil {
-        log.Fatalf("Failed to initialize Bedrock client: %v", err)
-    }
-
-    bc.TestModelAvailability()
-
-    router := mux.NewRouter()
-    
-    router.HandleFunc("/", rootHandler).Methods("GET")
-    router.HandleFunc("/health", healthHandler(bc)).Methods("GET")
-    router.HandleFunc("/generate", generateHandler(bc)).Methods("POST")
-
-    srv := &http.Server{
-        Handler:      router,
-        Addr:         ":9000",
-        WriteTimeout: 120 * time.Second,
-        ReadTimeout:  60 * time.Second,
-    }
-
-    log.Printf("Bedrock Service started on port 9000")
-    
-    if err := srv.ListenAndServe(); err != nil {
-        log.Fatal(err)
-    }
+	log.Println("Starting Bedrock Service...")
+
+	registry, bc, err := buildRegistry()
+	if err != nil {
+		log.Fatalf("Failed to initialize providers: %v", err)
+	}
+
+	cache, err := buildCache()
+	if err != nil {
+		log.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	router := mux.NewRouter()
+
+	router.HandleFunc("/", rootHandler).Methods("GET")
+	router.HandleFunc("/health", healthHandler(registry)).Methods("GET")
+	router.HandleFunc("/generate", generateHandler(registry, cache)).Methods("POST")
+	router.HandleFunc("/cache", cacheHandler(cache)).Methods("DELETE")
+	router.Handle("/metrics", metricsHandler()).Methods("GET")
+	if bc != nil {
+		router.HandleFunc("/generate/stream", generateStreamHandler(bc, cache)).Methods("POST")
+		router.HandleFunc("/embeddings", embeddingsHandler(bc)).Methods("POST")
+	}
+
+	srv := &http.Server{
+		Handler:      router,
+		Addr:         ":9000",
+		WriteTimeout: 120 * time.Second,
+		ReadTimeout:  60 * time.Second,
+	}
+
+	log.Printf("Bedrock Service started on port 9000")
+
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
 }
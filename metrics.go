@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"ai-helpdeskk/bedrock-service/provider"
+)
+
+var (
+	tokensTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bedrock_tokens_total",
+		Help: "Total tokens processed, labeled by model and direction (input/output).",
+	}, []string{"model", "direction"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "bedrock_request_duration_seconds",
+		Help:    "Generation request latency in seconds, labeled by model.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"model"})
+
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bedrock_requests_total",
+		Help: "Total generation requests, labeled by model and status (success/error).",
+	}, []string{"model", "status"})
+
+	cacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bedrock_cache_hits_total",
+		Help: "Total generation requests served from the response cache.",
+	})
+
+	cacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bedrock_cache_misses_total",
+		Help: "Total generation requests not found in the response cache.",
+	})
+)
+
+// recordGeneration updates the request/latency/token metrics for one
+// generation call. model should be the model that actually served the
+// request, or "unknown" if generation failed before a model was selected.
+func recordGeneration(model string, usage provider.Usage, duration time.Duration, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+
+	requestsTotal.WithLabelValues(model, status).Inc()
+	requestDuration.WithLabelValues(model).Observe(duration.Seconds())
+
+	if usage.InputTokens > 0 {
+		tokensTotal.WithLabelValues(model, "input").Add(float64(usage.InputTokens))
+	}
+	if usage.OutputTokens > 0 {
+		tokensTotal.WithLabelValues(model, "output").Add(float64(usage.OutputTokens))
+	}
+}
+
+func metricsHandler() http.Handler {
+	return promhttp.Handler()
+}
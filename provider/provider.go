@@ -0,0 +1,161 @@
+// Package provider defines the Provider interface that every generation
+// backend (Bedrock, Gemini, an OpenAI-compatible endpoint, ...) implements, and
+// the Registry that lets the service register several and fall back across
+// them transparently.
+package provider
+
+import (
+	"context"
+	"strings"
+)
+
+// Usage captures token accounting for a single generation call. Providers that
+// can't report real counts (e.g. legacy completion APIs) either fill it from
+// ApproxTokenCount themselves, as the bedrock provider does, or leave both
+// fields zero; callers use the values as returned.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// GenerateOptions carries the knobs a caller can set on a single Generate call,
+// independent of which Provider ends up serving it.
+type GenerateOptions struct {
+	Model       string
+	MaxTokens   int
+	Temperature float64
+}
+
+// WithDefaults fills in the same defaults Providers apply internally, so
+// callers that need the effective values up front (e.g. for a cache key) see
+// the same numbers the Provider will actually use.
+func (o GenerateOptions) WithDefaults() GenerateOptions {
+	if o.MaxTokens == 0 {
+		o.MaxTokens = 2000
+	}
+	if o.Temperature == 0 {
+		o.Temperature = 0.7
+	}
+	return o
+}
+
+// Provider is a text-generation backend. The bedrock, gemini, and
+// openaicompat packages each implement it so the service can register several
+// and fall back across them transparently.
+type Provider interface {
+	// Name identifies the provider for routing (GenerateRequest.Provider) and
+	// for grouping models in the /health response, e.g. "bedrock", "google", "openai".
+	Name() string
+
+	// Generate produces a completion for prompt using opts, returning the text,
+	// the specific model that served it, and usage if the provider reports it.
+	Generate(ctx context.Context, prompt string, opts GenerateOptions) (text string, modelUsed string, usage Usage, err error)
+
+	// ListModels returns the names of models this provider currently considers
+	// available, for /health reporting.
+	ListModels() []string
+}
+
+// Registry holds the providers configured for this process and the order in
+// which they're tried when a request doesn't pin a provider, or when the
+// pinned provider's own models are all exhausted.
+type Registry struct {
+	providers    map[string]Provider
+	defaultOrder []string
+}
+
+// NewRegistry returns an empty registry; call Register to add providers.
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Provider)}
+}
+
+// Register adds a provider and appends it to the default fallback order.
+func (r *Registry) Register(p Provider) {
+	r.providers[p.Name()] = p
+	r.defaultOrder = append(r.defaultOrder, p.Name())
+}
+
+// Get returns the named provider, if registered.
+func (r *Registry) Get(name string) (Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Len reports how many providers are registered.
+func (r *Registry) Len() int {
+	return len(r.defaultOrder)
+}
+
+// ModelsByProvider groups each registered provider's available models under its
+// name, for the /health endpoint.
+func (r *Registry) ModelsByProvider() map[string][]string {
+	models := make(map[string][]string, len(r.providers))
+	for _, name := range r.defaultOrder {
+		models[name] = r.providers[name].ListModels()
+	}
+	return models
+}
+
+// Generate tries preferredProvider first (if set and registered), then falls
+// back across the remaining registered providers in registration order. This
+// lets a Bedrock outage degrade transparently to Gemini or a local
+// OpenAI-compatible server rather than just across Bedrock model IDs.
+func (r *Registry) Generate(ctx context.Context, prompt string, preferredProvider string, opts GenerateOptions) (text string, modelUsed string, providerUsed string, usage Usage, err error) {
+	order := r.order(preferredProvider)
+	if len(order) == 0 {
+		return "", "", "", Usage{}, errNoProviders
+	}
+
+	var lastErr error
+	for _, name := range order {
+		p := r.providers[name]
+		text, modelUsed, usage, err := p.Generate(ctx, prompt, opts)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return text, modelUsed, name, usage, nil
+	}
+
+	return "", "", "", Usage{}, &allProvidersFailedError{lastErr}
+}
+
+// order returns provider names with preferred (if registered) first, followed
+// by the rest of defaultOrder.
+func (r *Registry) order(preferred string) []string {
+	if preferred == "" {
+		return r.defaultOrder
+	}
+	if _, ok := r.providers[preferred]; !ok {
+		return r.defaultOrder
+	}
+
+	order := make([]string, 0, len(r.defaultOrder))
+	order = append(order, preferred)
+	for _, name := range r.defaultOrder {
+		if name != preferred {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+var errNoProviders = &allProvidersFailedError{nil}
+
+type allProvidersFailedError struct {
+	last error
+}
+
+func (e *allProvidersFailedError) Error() string {
+	if e.last == nil {
+		return "no providers registered"
+	}
+	return "all providers failed. Last error: " + e.last.Error()
+}
+
+// ApproxTokenCount whitespace-tokenizes s as a rough stand-in for usage on
+// providers that don't report real token counts, such as legacy Claude v2
+// completions.
+func ApproxTokenCount(s string) int {
+	return len(strings.Fields(s))
+}
@@ -0,0 +1,531 @@
+// Package bedrock implements provider.Provider against the AWS Bedrock
+// Runtime, across both Anthropic chat models and Titan/Cohere embedding
+// models.
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+
+	"ai-helpdeskk/bedrock-service/provider"
+)
+
+// ModelKind distinguishes text-generation models from embedding models, since
+// they're probed and invoked with different request shapes.
+type ModelKind string
+
+const (
+	ModelKindChat      ModelKind = "chat"
+	ModelKindEmbedding ModelKind = "embedding"
+)
+
+type ModelInfo struct {
+	// ID is the raw model identifier passed to InvokeModel. It may be a short
+	// on-demand model ID ("anthropic.claude-3-5-sonnet-...") or a full
+	// cross-region inference-profile / application-inference-profile ARN
+	// ("arn:aws:bedrock:...:inference-profile/...").
+	ID         string
+	Name       string
+	Available  bool
+	MessageAPI bool
+	Kind       ModelKind
+}
+
+type Client struct {
+	client          *bedrockruntime.Client
+	availableModels []ModelInfo
+}
+
+func NewClient() (*Client, error) {
+	awsAccessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	awsSecretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	awsRegion := os.Getenv("AWS_REGION")
+
+	if awsRegion == "" {
+		awsRegion = "us-east-1"
+	}
+
+	if awsAccessKey == "" || awsSecretKey == "" {
+		return nil, fmt.Errorf("AWS credentials not provided")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithRegion(awsRegion),
+		config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(awsAccessKey, awsSecretKey, ""),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+	}
+
+	client := bedrockruntime.NewFromConfig(cfg)
+
+	availableModels := []ModelInfo{
+		{ID: "anthropic.claude-3-5-sonnet-20241022-v2:0", Name: "Claude 3.5 Sonnet v2", MessageAPI: true, Kind: ModelKindChat},
+		{ID: "anthropic.claude-3-5-sonnet-20240620-v1:0", Name: "Claude 3.5 Sonnet", MessageAPI: true, Kind: ModelKindChat},
+		{ID: "anthropic.claude-3-5-haiku-20241022-v1:0", Name: "Claude 3.5 Haiku", MessageAPI: true, Kind: ModelKindChat},
+		{ID: "anthropic.claude-3-sonnet-20240229-v1:0", Name: "Claude 3 Sonnet", MessageAPI: true, Kind: ModelKindChat},
+		{ID: "anthropic.claude-3-haiku-20240307-v1:0", Name: "Claude 3 Haiku", MessageAPI: true, Kind: ModelKindChat},
+		{ID: "anthropic.claude-v2:1", Name: "Claude v2.1", MessageAPI: false, Kind: ModelKindChat},
+		{ID: "anthropic.claude-v2", Name: "Claude v2", MessageAPI: false, Kind: ModelKindChat},
+		{ID: "amazon.titan-embed-text-v2:0", Name: "Titan Embed Text v2", Kind: ModelKindEmbedding},
+		{ID: "amazon.titan-embed-text-v1", Name: "Titan Embed Text v1", Kind: ModelKindEmbedding},
+		{ID: "cohere.embed-english-v3", Name: "Cohere Embed English v3", Kind: ModelKindEmbedding},
+		{ID: "cohere.embed-multilingual-v3", Name: "Cohere Embed Multilingual v3", Kind: ModelKindEmbedding},
+	}
+
+	for _, id := range extraModelIdentifiers() {
+		availableModels = append(availableModels, ModelInfo{
+			ID:         id,
+			Name:       modelNameFromIdentifier(id),
+			MessageAPI: !isLegacyClaudeV2(id),
+			Kind:       ModelKindChat,
+		})
+	}
+
+	return &Client{
+		client:          client,
+		availableModels: availableModels,
+	}, nil
+}
+
+// extraModelIdentifiers parses BEDROCK_EXTRA_MODELS, a comma-separated list of
+// model IDs and/or inference-profile ARNs, so operators can register new
+// inference profiles without recompiling.
+func extraModelIdentifiers() []string {
+	raw := os.Getenv("BEDROCK_EXTRA_MODELS")
+	if raw == "" {
+		return nil
+	}
+
+	var ids []string
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			ids = append(ids, entry)
+		}
+	}
+	return ids
+}
+
+// isARN reports whether identifier is a full ARN rather than a short model ID.
+func isARN(identifier string) bool {
+	return strings.HasPrefix(identifier, "arn:")
+}
+
+// isLegacyClaudeV2 reports whether identifier resolves to a legacy
+// `prompt`/`completion` model rather than the Messages API, based on the
+// trailing resource segment when identifier is an ARN.
+func isLegacyClaudeV2(identifier string) bool {
+	resource := identifier
+	if isARN(identifier) {
+		parts := strings.Split(identifier, "/")
+		resource = parts[len(parts)-1]
+	}
+	lower := strings.ToLower(resource)
+	return strings.Contains(lower, "claude-v2") || strings.Contains(lower, "claude-instant")
+}
+
+// modelNameFromIdentifier derives a display name for a model registered via
+// BEDROCK_EXTRA_MODELS: the trailing resource segment for ARNs, or the
+// identifier itself for short IDs.
+func modelNameFromIdentifier(identifier string) string {
+	if !isARN(identifier) {
+		return identifier
+	}
+	parts := strings.Split(identifier, "/")
+	return parts[len(parts)-1]
+}
+
+func (bc *Client) TestModelAvailability() {
+	log.Println("Testing model availability...")
+
+	testPrompt := "Hello"
+
+	for i := range bc.availableModels {
+		model := &bc.availableModels[i]
+
+		var requestBody map[string]interface{}
+
+		switch {
+		case model.Kind == ModelKindEmbedding && strings.HasPrefix(model.ID, "cohere."):
+			requestBody = map[string]interface{}{
+				"texts":      []string{testPrompt},
+				"input_type": "search_document",
+			}
+		case model.Kind == ModelKindEmbedding:
+			requestBody = map[string]interface{}{
+				"inputText": testPrompt,
+			}
+		case model.MessageAPI:
+			requestBody = map[string]interface{}{
+				"anthropic_version": "bedrock-2023-05-31",
+				"max_tokens":        10,
+				"messages": []map[string]string{
+					{
+						"role":    "user",
+						"content": testPrompt,
+					},
+				},
+			}
+		default:
+			requestBody = map[string]interface{}{
+				"prompt":               fmt.Sprintf("\n\nHuman: %s\n\nAssistant:", testPrompt),
+				"max_tokens_to_sample": 10,
+			}
+		}
+
+		bodyBytes, _ := json.Marshal(requestBody)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		_, err := bc.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+			Body:        bodyBytes,
+			ModelId:     aws.String(model.ID),
+			ContentType: aws.String("application/json"),
+		})
+		cancel()
+
+		if err != nil {
+			if isARN(model.ID) && strings.Contains(err.Error(), "AccessDeniedException") {
+				// Inference profiles can reject a trivial probe call (the
+				// caller's IAM policy may scope access to specific
+				// applications) yet still succeed on real traffic. Soft-fail
+				// instead of removing the entry from the fallback list.
+				log.Printf("Model %s (%s): PROBE DENIED, keeping available (inference profile) - %v", model.Name, model.ID, err)
+				model.Available = true
+				continue
+			}
+			log.Printf("Model %s (%s): UNAVAILABLE - %v", model.Name, model.ID, err)
+			model.Available = false
+		} else {
+			log.Printf("Model %s (%s): AVAILABLE ✓", model.Name, model.ID)
+			model.Available = true
+		}
+	}
+}
+
+func (bc *Client) GetAvailableModels() []string {
+	var available []string
+	for _, model := range bc.availableModels {
+		if model.Available && model.Kind == ModelKindChat {
+			available = append(available, model.Name)
+		}
+	}
+	return available
+}
+
+func (bc *Client) GenerateText(ctx context.Context, prompt string, preferredModel string, maxTokens int, temperature float64) (string, string, provider.Usage, error) {
+	if maxTokens == 0 {
+		maxTokens = 2000
+	}
+	if temperature == 0 {
+		temperature = 0.7
+	}
+
+	modelsToTry := bc.orderedModels(preferredModel)
+	if len(modelsToTry) == 0 {
+		return "", "", provider.Usage{}, fmt.Errorf("no available models found")
+	}
+
+	var lastError error
+	for _, model := range modelsToTry {
+		log.Printf("Trying model: %s (%s)", model.Name, model.ID)
+
+		requestBody := bc.buildRequestBody(model, prompt, maxTokens, temperature)
+
+		bodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			lastError = fmt.Errorf("error marshaling request: %v", err)
+			continue
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		resp, err := bc.client.InvokeModel(callCtx, &bedrockruntime.InvokeModelInput{
+			Body:        bodyBytes,
+			ModelId:     aws.String(model.ID),
+			ContentType: aws.String("application/json"),
+		})
+		cancel()
+
+		if err != nil {
+			lastError = err
+			log.Printf("Error with model %s: %v", model.Name, err)
+			continue
+		}
+
+		var response map[string]interface{}
+		if err := json.Unmarshal(resp.Body, &response); err != nil {
+			lastError = fmt.Errorf("error parsing response: %v", err)
+			continue
+		}
+
+		if model.MessageAPI {
+			if content, ok := response["content"].([]interface{}); ok && len(content) > 0 {
+				if firstContent, ok := content[0].(map[string]interface{}); ok {
+					if text, ok := firstContent["text"].(string); ok {
+						log.Printf("✓ Successfully used model: %s", model.Name)
+						return text, model.Name, messageAPIUsage(response, prompt, text), nil
+					}
+				}
+			}
+		} else {
+			if completion, ok := response["completion"].(string); ok {
+				log.Printf("✓ Successfully used model: %s", model.Name)
+				return completion, model.Name, provider.Usage{InputTokens: provider.ApproxTokenCount(prompt), OutputTokens: provider.ApproxTokenCount(completion)}, nil
+			}
+		}
+
+		lastError = fmt.Errorf("unexpected response format from model %s", model.Name)
+	}
+
+	return "", "", provider.Usage{}, fmt.Errorf("all available models failed. Last error: %v", lastError)
+}
+
+// messageAPIUsage extracts input_tokens/output_tokens from a Messages API
+// response's "usage" object, falling back to whitespace-tokenization of
+// prompt/text if the provider omitted it.
+func messageAPIUsage(response map[string]interface{}, prompt, text string) provider.Usage {
+	usage, ok := response["usage"].(map[string]interface{})
+	if !ok {
+		return provider.Usage{InputTokens: provider.ApproxTokenCount(prompt), OutputTokens: provider.ApproxTokenCount(text)}
+	}
+
+	inputTokens, _ := usage["input_tokens"].(float64)
+	outputTokens, _ := usage["output_tokens"].(float64)
+	if inputTokens == 0 && outputTokens == 0 {
+		return provider.Usage{InputTokens: provider.ApproxTokenCount(prompt), OutputTokens: provider.ApproxTokenCount(text)}
+	}
+	return provider.Usage{InputTokens: int(inputTokens), OutputTokens: int(outputTokens)}
+}
+
+// GenerateTextStream behaves like GenerateText but streams response deltas to onDelta
+// as they arrive from Bedrock. Fallback across modelsToTry only happens while no bytes
+// have been delivered to onDelta yet; once streaming has started, an error from Bedrock
+// aborts the stream instead of silently retrying with a different model underneath the
+// caller.
+func (bc *Client) GenerateTextStream(ctx context.Context, prompt string, preferredModel string, maxTokens int, temperature float64, onDelta func(text string)) (string, provider.Usage, error) {
+	if maxTokens == 0 {
+		maxTokens = 2000
+	}
+	if temperature == 0 {
+		temperature = 0.7
+	}
+
+	modelsToTry := bc.orderedModels(preferredModel)
+	if len(modelsToTry) == 0 {
+		return "", provider.Usage{}, fmt.Errorf("no available models found")
+	}
+
+	var lastError error
+	for _, model := range modelsToTry {
+		log.Printf("Trying model (stream): %s (%s)", model.Name, model.ID)
+
+		requestBody := bc.buildRequestBody(model, prompt, maxTokens, temperature)
+
+		bodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			lastError = fmt.Errorf("error marshaling request: %v", err)
+			continue
+		}
+
+		out, err := bc.client.InvokeModelWithResponseStream(ctx, &bedrockruntime.InvokeModelWithResponseStreamInput{
+			Body:        bodyBytes,
+			ModelId:     aws.String(model.ID),
+			ContentType: aws.String("application/json"),
+		})
+		if err != nil {
+			lastError = err
+			log.Printf("Error starting stream with model %s: %v", model.Name, err)
+			continue
+		}
+
+		wrote := false
+		var generated strings.Builder
+		usage := provider.Usage{}
+		streamErr := consumeStream(ctx, out.GetStream(), model.MessageAPI, func(delta string) {
+			wrote = true
+			generated.WriteString(delta)
+			onDelta(delta)
+		}, &usage)
+
+		if streamErr != nil {
+			if wrote {
+				return "", usage, fmt.Errorf("stream from model %s failed after writing partial response: %v", model.Name, streamErr)
+			}
+			lastError = streamErr
+			log.Printf("Error streaming from model %s: %v", model.Name, streamErr)
+			continue
+		}
+
+		if usage.InputTokens == 0 && usage.OutputTokens == 0 {
+			usage = provider.Usage{InputTokens: provider.ApproxTokenCount(prompt), OutputTokens: provider.ApproxTokenCount(generated.String())}
+		}
+
+		log.Printf("✓ Successfully streamed using model: %s", model.Name)
+		return model.Name, usage, nil
+	}
+
+	return "", provider.Usage{}, fmt.Errorf("all available models failed. Last error: %v", lastError)
+}
+
+// consumeStream reads chunks off a Bedrock InvokeModelWithResponseStream event stream,
+// extracting the delta text from each PayloadPart and handing it to onChunk. If a chunk
+// carries the terminal amazon-bedrock-invocationMetrics block, usage is populated from it.
+func consumeStream(ctx context.Context, stream *bedrockruntime.InvokeModelWithResponseStreamEventStream, messageAPI bool, onChunk func(delta string), usage *provider.Usage) error {
+	defer stream.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-stream.Events():
+			if !ok {
+				return stream.Err()
+			}
+
+			switch v := event.(type) {
+			case *types.ResponseStreamMemberChunk:
+				delta, err := extractDelta(v.Value.Bytes, messageAPI, usage)
+				if err != nil {
+					return err
+				}
+				if delta != "" {
+					onChunk(delta)
+				}
+			default:
+				// Unknown event type; ignore.
+			}
+		}
+	}
+}
+
+// extractDelta pulls the incremental text out of a single Messages-API or legacy
+// completion chunk, and records invocation metrics into usage if the chunk carries them.
+func extractDelta(raw []byte, messageAPI bool, usage *provider.Usage) (string, error) {
+	var chunk map[string]interface{}
+	if err := json.Unmarshal(raw, &chunk); err != nil {
+		return "", fmt.Errorf("error parsing chunk: %v", err)
+	}
+
+	if metrics, ok := chunk["amazon-bedrock-invocationMetrics"].(map[string]interface{}); ok {
+		if input, ok := metrics["inputTokenCount"].(float64); ok {
+			usage.InputTokens = int(input)
+		}
+		if output, ok := metrics["outputTokenCount"].(float64); ok {
+			usage.OutputTokens = int(output)
+		}
+	}
+
+	if messageAPI {
+		chunkType, _ := chunk["type"].(string)
+		switch chunkType {
+		case "content_block_delta":
+			if delta, ok := chunk["delta"].(map[string]interface{}); ok {
+				if text, ok := delta["text"].(string); ok {
+					return text, nil
+				}
+			}
+		case "message_stop", "message_start", "content_block_start", "content_block_stop", "ping":
+			// No text to forward.
+		}
+		return "", nil
+	}
+
+	if completion, ok := chunk["completion"].(string); ok {
+		return completion, nil
+	}
+	return "", nil
+}
+
+// orderedModels returns the available chat models with preferredModel (if any) moved to
+// the front, preserving the rest of the fallback order. Embedding models are never
+// candidates here: buildRequestBody only knows how to shape chat (Messages API or legacy
+// completion) requests, so handing it an embedding model would send a guaranteed-wrong
+// request body to /generate or /generate/stream.
+func (bc *Client) orderedModels(preferredModel string) []ModelInfo {
+	var modelsToTry []ModelInfo
+	if preferredModel != "" {
+		for _, model := range bc.availableModels {
+			if model.Available && model.Kind == ModelKindChat && (strings.Contains(strings.ToLower(model.Name), strings.ToLower(preferredModel)) ||
+				strings.Contains(strings.ToLower(model.ID), strings.ToLower(preferredModel))) {
+				modelsToTry = append(modelsToTry, model)
+				break
+			}
+		}
+	}
+
+	for _, model := range bc.availableModels {
+		if model.Available && model.Kind == ModelKindChat {
+			found := false
+			for _, existing := range modelsToTry {
+				if existing.ID == model.ID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				modelsToTry = append(modelsToTry, model)
+			}
+		}
+	}
+	return modelsToTry
+}
+
+// DefaultSystemPrompt is sent with every Messages API call. It's also the
+// systemPrompt component of the response cache key, since it affects output.
+const DefaultSystemPrompt = "You are a helpful AI assistant with access to conversation history and uploaded files. " +
+	"When responding, consider the full context provided, including previous conversations and any file content. " +
+	"If file content is mentioned in the context, analyze and reference it appropriately in your response. " +
+	"Be conversational, helpful, and maintain continuity with previous interactions."
+
+// buildRequestBody constructs the Bedrock InvokeModel request body for a given model,
+// matching the Messages API or legacy completion shape as appropriate.
+func (bc *Client) buildRequestBody(model ModelInfo, prompt string, maxTokens int, temperature float64) map[string]interface{} {
+	if model.MessageAPI {
+		return map[string]interface{}{
+			"anthropic_version": "bedrock-2023-05-31",
+			"max_tokens":        maxTokens,
+			"system":            DefaultSystemPrompt,
+			"messages": []map[string]interface{}{
+				{
+					"role":    "user",
+					"content": prompt,
+				},
+			},
+			"temperature": temperature,
+		}
+	}
+
+	enhancedPrompt := fmt.Sprintf("\n\nHuman: You are a helpful AI assistant with conversation memory and file analysis capabilities. Please provide thoughtful, contextual responses based on the information provided.\n\n%s\n\nAssistant:", prompt)
+
+	return map[string]interface{}{
+		"prompt":               enhancedPrompt,
+		"max_tokens_to_sample": maxTokens,
+		"temperature":          temperature,
+	}
+}
+
+// Name identifies this provider for request routing and /health grouping.
+func (bc *Client) Name() string { return "bedrock" }
+
+// Generate implements provider.Provider by delegating to GenerateText.
+func (bc *Client) Generate(ctx context.Context, prompt string, opts provider.GenerateOptions) (string, string, provider.Usage, error) {
+	return bc.GenerateText(ctx, prompt, opts.Model, opts.MaxTokens, opts.Temperature)
+}
+
+// ListModels implements provider.Provider.
+func (bc *Client) ListModels() []string {
+	return bc.GetAvailableModels()
+}
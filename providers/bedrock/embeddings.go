@@ -0,0 +1,155 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+
+	"ai-helpdeskk/bedrock-service/provider"
+)
+
+// embedWorkerCount bounds how many Titan single-input InvokeModel calls run
+// concurrently for one batch; Cohere models accept a batch of texts in a
+// single call and don't need fan-out.
+const embedWorkerCount = 4
+
+// embeddingModel picks the first available embedding model matching
+// preferredModel (by Name or ID substring), or the first available embedding
+// model at all if preferredModel is empty. A non-empty preferredModel that
+// matches no available embedding model reports false.
+func (bc *Client) embeddingModel(preferredModel string) (ModelInfo, bool) {
+	var fallback ModelInfo
+	haveFallback := false
+
+	for _, model := range bc.availableModels {
+		if model.Kind != ModelKindEmbedding || !model.Available {
+			continue
+		}
+		if !haveFallback {
+			fallback = model
+			haveFallback = true
+		}
+		if preferredModel != "" &&
+			(strings.Contains(strings.ToLower(model.Name), strings.ToLower(preferredModel)) ||
+				strings.Contains(strings.ToLower(model.ID), strings.ToLower(preferredModel))) {
+			return model, true
+		}
+	}
+
+	if preferredModel == "" && haveFallback {
+		return fallback, true
+	}
+	return ModelInfo{}, false
+}
+
+// Embed generates embedding vectors for inputs using preferredModel (or the
+// first available embedding model). Titan only accepts one inputText per
+// call, so those are fanned out across a bounded worker pool; Cohere accepts
+// a batch directly. Both are normalized into the same [][]float32 shape.
+func (bc *Client) Embed(ctx context.Context, inputs []string, preferredModel string) ([][]float32, string, int, error) {
+	model, ok := bc.embeddingModel(preferredModel)
+	if !ok {
+		return nil, "", 0, fmt.Errorf("no available embedding models found")
+	}
+
+	if strings.HasPrefix(model.ID, "cohere.") {
+		vectors, tokenCount, err := bc.embedCohereBatch(ctx, model.ID, inputs)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		return vectors, model.Name, tokenCount, nil
+	}
+
+	vectors := make([][]float32, len(inputs))
+	tokenCounts := make([]int, len(inputs))
+	errs := make([]error, len(inputs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < embedWorkerCount; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				vectors[i], tokenCounts[i], errs[i] = bc.embedTitanSingle(ctx, model.ID, inputs[i])
+			}
+		}()
+	}
+	for i := range inputs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	total := 0
+	for i, err := range errs {
+		if err != nil {
+			return nil, "", 0, fmt.Errorf("error embedding input %d: %v", i, err)
+		}
+		total += tokenCounts[i]
+	}
+
+	return vectors, model.Name, total, nil
+}
+
+func (bc *Client) embedTitanSingle(ctx context.Context, modelID, input string) ([]float32, int, error) {
+	bodyBytes, err := json.Marshal(map[string]string{"inputText": input})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	resp, err := bc.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		Body:        bodyBytes,
+		ModelId:     aws.String(modelID),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var parsed struct {
+		Embedding           []float32 `json:"embedding"`
+		InputTextTokenCount int       `json:"inputTextTokenCount"`
+	}
+	if err := json.Unmarshal(resp.Body, &parsed); err != nil {
+		return nil, 0, fmt.Errorf("error parsing response: %v", err)
+	}
+	return parsed.Embedding, parsed.InputTextTokenCount, nil
+}
+
+func (bc *Client) embedCohereBatch(ctx context.Context, modelID string, inputs []string) ([][]float32, int, error) {
+	bodyBytes, err := json.Marshal(map[string]interface{}{
+		"texts":      inputs,
+		"input_type": "search_document",
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	resp, err := bc.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		Body:        bodyBytes,
+		ModelId:     aws.String(modelID),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var parsed struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.Unmarshal(resp.Body, &parsed); err != nil {
+		return nil, 0, fmt.Errorf("error parsing response: %v", err)
+	}
+
+	tokenCount := 0
+	for _, text := range inputs {
+		tokenCount += provider.ApproxTokenCount(text)
+	}
+	return parsed.Embeddings, tokenCount, nil
+}
@@ -0,0 +1,128 @@
+// Package gemini implements provider.Provider against the Google GenAI
+// (Gemini) generateContent REST API.
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"ai-helpdeskk/bedrock-service/provider"
+)
+
+const apiBase = "https://generativelanguage.googleapis.com/v1beta/models"
+
+// Provider calls the Google GenAI (Gemini) generateContent REST API,
+// configured via GOOGLE_API_KEY.
+type Provider struct {
+	apiKey string
+	models []string
+	client *http.Client
+}
+
+// New builds a provider from GOOGLE_API_KEY / GOOGLE_MODELS (comma-separated,
+// defaults to "gemini-1.5-pro" and "gemini-1.5-flash").
+func New() *Provider {
+	models := []string{"gemini-1.5-pro", "gemini-1.5-flash"}
+	if raw := os.Getenv("GOOGLE_MODELS"); raw != "" {
+		models = strings.Split(raw, ",")
+		for i := range models {
+			models[i] = strings.TrimSpace(models[i])
+		}
+	}
+
+	return &Provider{
+		apiKey: os.Getenv("GOOGLE_API_KEY"),
+		models: models,
+		client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string { return "google" }
+
+func (p *Provider) ListModels() []string { return p.models }
+
+func (p *Provider) Generate(ctx context.Context, prompt string, opts provider.GenerateOptions) (string, string, provider.Usage, error) {
+	model := opts.Model
+	if model == "" {
+		model = p.models[0]
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 2000
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.7
+	}
+
+	requestBody := map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{
+				"role":  "user",
+				"parts": []map[string]string{{"text": prompt}},
+			},
+		},
+		"generationConfig": map[string]interface{}{
+			"maxOutputTokens": maxTokens,
+			"temperature":     temperature,
+		},
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/%s:generateContent?key=%s", apiBase, model, p.apiKey)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error building request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error calling Gemini: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error reading response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", provider.Usage{}, fmt.Errorf("gemini returned %d: %s", resp.StatusCode, respBytes)
+	}
+
+	var parsed struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+	}
+	if err := json.Unmarshal(respBytes, &parsed); err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error parsing response: %v", err)
+	}
+	if len(parsed.Candidates) == 0 || len(parsed.Candidates[0].Content.Parts) == 0 {
+		return "", "", provider.Usage{}, fmt.Errorf("unexpected response format from Gemini")
+	}
+
+	usage := provider.Usage{InputTokens: parsed.UsageMetadata.PromptTokenCount, OutputTokens: parsed.UsageMetadata.CandidatesTokenCount}
+	return parsed.Candidates[0].Content.Parts[0].Text, model, usage, nil
+}
@@ -0,0 +1,125 @@
+// Package openaicompat implements provider.Provider against any
+// OpenAI-compatible chat completions endpoint.
+package openaicompat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"ai-helpdeskk/bedrock-service/provider"
+)
+
+// Provider talks to any OpenAI-compatible chat completions endpoint (OpenAI
+// itself, a local vLLM/Ollama/LM Studio server, etc.), selected via
+// OPENAI_BASE_URL and optionally OPENAI_API_KEY.
+type Provider struct {
+	baseURL string
+	apiKey  string
+	models  []string
+	client  *http.Client
+}
+
+// New builds a provider from OPENAI_BASE_URL / OPENAI_API_KEY / OPENAI_MODELS
+// (comma-separated, defaults to a single "gpt-4o-mini" entry).
+func New() *Provider {
+	models := []string{"gpt-4o-mini"}
+	if raw := os.Getenv("OPENAI_MODELS"); raw != "" {
+		models = strings.Split(raw, ",")
+		for i := range models {
+			models[i] = strings.TrimSpace(models[i])
+		}
+	}
+
+	return &Provider{
+		baseURL: strings.TrimRight(os.Getenv("OPENAI_BASE_URL"), "/"),
+		apiKey:  os.Getenv("OPENAI_API_KEY"),
+		models:  models,
+		client:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string { return "openai" }
+
+func (p *Provider) ListModels() []string { return p.models }
+
+func (p *Provider) Generate(ctx context.Context, prompt string, opts provider.GenerateOptions) (string, string, provider.Usage, error) {
+	model := opts.Model
+	if model == "" {
+		model = p.models[0]
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 2000
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = 0.7
+	}
+
+	requestBody := map[string]interface{}{
+		"model": model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"max_tokens":  maxTokens,
+		"temperature": temperature,
+	}
+
+	bodyBytes, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error marshaling request: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error building request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error calling %s: %v", p.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error reading response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", provider.Usage{}, fmt.Errorf("openai-compatible endpoint returned %d: %s", resp.StatusCode, respBytes)
+	}
+
+	var parsed struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(respBytes, &parsed); err != nil {
+		return "", "", provider.Usage{}, fmt.Errorf("error parsing response: %v", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", "", provider.Usage{}, fmt.Errorf("unexpected response format from %s", p.baseURL)
+	}
+
+	usage := provider.Usage{InputTokens: parsed.Usage.PromptTokens, OutputTokens: parsed.Usage.CompletionTokens}
+	return parsed.Choices[0].Message.Content, model, usage, nil
+}